@@ -0,0 +1,137 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/google/go-github/v45/github"
+)
+
+// checkDirectPushAllowed fetches the branch protection rules and CODEOWNERS
+// file for a repository and returns an error if the authenticated identity
+// cannot push `path` to `branch` directly, e.g. because pull request reviews
+// are required or the path is covered by a CODEOWNERS entry that requires
+// review. It runs at the start of apply, before any commit is attempted,
+// rather than letting GitHub reject the write halfway through an apply.
+func checkDirectPushAllowed(ctx context.Context, client *github.Client, owner, repo, branch, filePath string) error {
+	protection, resp, err := client.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			// No branch protection configured for this branch.
+			return nil
+		}
+		return fmt.Errorf("unable to check branch protection for %s: %s", branch, err)
+	}
+
+	if restrictions := protection.GetRestrictions(); restrictions != nil {
+		canPush, err := identityAllowedByRestrictions(ctx, client, owner, restrictions)
+		if err != nil {
+			return err
+		}
+		if !canPush {
+			return fmt.Errorf("branch %q restricts who can push and the authenticated identity is not on the allowed users/teams list; configure `bypass_pull_request = true` if that is incorrect, or `require_pull_request = true` to open a pull request instead", branch)
+		}
+	}
+
+	reviews := protection.GetRequiredPullRequestReviews()
+	if reviews == nil {
+		return nil
+	}
+
+	if reviews.RequireCodeOwnerReviews {
+		owned, err := codeownersMatch(ctx, client, owner, repo, branch, filePath)
+		if err != nil {
+			return err
+		}
+
+		if owned {
+			return fmt.Errorf("branch %q requires review from a CODEOWNERS entry matching %q; set `require_pull_request = true` to open a pull request instead of pushing directly", branch, filePath)
+		}
+	}
+
+	return fmt.Errorf("branch %q requires pull request reviews before changes can be merged; set `require_pull_request = true` to open a pull request instead of pushing directly", branch)
+}
+
+// identityAllowedByRestrictions reports whether the authenticated identity
+// is on the push restriction's allowed users or teams. App restrictions
+// aren't checked here, since there is no reliable way to map the provider's
+// token back to an installed app; an app-restricted branch is treated as
+// blocked unless the user/team check above already passed.
+func identityAllowedByRestrictions(ctx context.Context, client *github.Client, owner string, restrictions *github.BranchRestrictions) (bool, error) {
+	user, _, err := client.Users.Get(ctx, "")
+	if err != nil {
+		return false, fmt.Errorf("unable to determine the authenticated user: %s", err)
+	}
+	login := user.GetLogin()
+
+	for _, allowedUser := range restrictions.Users {
+		if strings.EqualFold(allowedUser.GetLogin(), login) {
+			return true, nil
+		}
+	}
+
+	for _, team := range restrictions.Teams {
+		if _, _, err := client.Teams.GetTeamMembershipBySlug(ctx, owner, team.GetSlug(), login); err == nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// codeownersLocations lists the paths GitHub looks for a CODEOWNERS file in,
+// in the order it checks them.
+var codeownersLocations = []string{"CODEOWNERS", "docs/CODEOWNERS", ".github/CODEOWNERS"}
+
+// codeownersMatch reports whether filePath is covered by any pattern in the
+// repository's CODEOWNERS file on branch. Patterns are matched with
+// path.Match, which is a reasonable approximation of the gitignore-style
+// globs CODEOWNERS supports for the common single-segment cases.
+func codeownersMatch(ctx context.Context, client *github.Client, owner, repo, branch, filePath string) (bool, error) {
+	for _, location := range codeownersLocations {
+		content, _, resp, err := client.Repositories.GetContents(ctx, owner, repo, location, &github.RepositoryContentGetOptions{Ref: branch})
+		if err != nil {
+			if resp != nil && resp.StatusCode == 404 {
+				continue
+			}
+			return false, fmt.Errorf("unable to fetch %s: %s", location, err)
+		}
+		if content == nil {
+			continue
+		}
+
+		body, err := content.GetContent()
+		if err != nil {
+			return false, fmt.Errorf("unable to read %s: %s", location, err)
+		}
+
+		for _, line := range strings.Split(body, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			fields := strings.Fields(line)
+			pattern := strings.TrimPrefix(fields[0], "/")
+			if len(fields) > 1 && codeownersPatternMatches(pattern, filePath) {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	return false, nil
+}
+
+func codeownersPatternMatches(pattern, filePath string) bool {
+	pattern = strings.TrimSuffix(pattern, "/")
+	if pattern == "*" || pattern == filePath || strings.HasPrefix(filePath, pattern+"/") {
+		return true
+	}
+
+	matched, err := path.Match(pattern, filePath)
+	return err == nil && matched
+}
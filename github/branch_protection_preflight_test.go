@@ -0,0 +1,25 @@
+package github
+
+import "testing"
+
+func TestCodeownersPatternMatches(t *testing.T) {
+	cases := []struct {
+		pattern  string
+		filePath string
+		want     bool
+	}{
+		{"*", "anything.txt", true},
+		{"docs/guide.md", "docs/guide.md", true},
+		{"docs/guide.md", "docs/other.md", false},
+		{"docs/", "docs/guide.md", true},
+		{"docs/", "other/docs/guide.md", false},
+		{"*.tf", "main.tf", true},
+		{"*.tf", "main.go", false},
+	}
+
+	for _, c := range cases {
+		if got := codeownersPatternMatches(c.pattern, c.filePath); got != c.want {
+			t.Errorf("codeownersPatternMatches(%q, %q) = %v, want %v", c.pattern, c.filePath, got, c.want)
+		}
+	}
+}
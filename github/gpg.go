@@ -0,0 +1,115 @@
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/openpgp"
+)
+
+// loadGPGSigningEntity parses an ASCII-armored PGP private key and, if it is
+// passphrase protected, decrypts it so that it can be used to sign Git
+// objects. It returns the first entity found in the key ring.
+func loadGPGSigningEntity(secretKey, passphrase string) (*openpgp.Entity, error) {
+	keyRing, err := openpgp.ReadArmoredKeyRing(strings.NewReader(secretKey))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse gpg_secret_key: %s", err)
+	}
+
+	if len(keyRing) == 0 {
+		return nil, fmt.Errorf("gpg_secret_key did not contain any PGP keys")
+	}
+
+	entity := keyRing[0]
+	if entity.PrivateKey == nil {
+		return nil, fmt.Errorf("gpg_secret_key does not contain a private key")
+	}
+
+	if entity.PrivateKey.Encrypted {
+		if passphrase == "" {
+			return nil, fmt.Errorf("gpg_secret_key is passphrase protected but no gpg_passphrase was provided")
+		}
+
+		if err := entity.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+			return nil, fmt.Errorf("unable to decrypt gpg_secret_key with the supplied gpg_passphrase: %s", err)
+		}
+
+		for _, subKey := range entity.Subkeys {
+			if subKey.PrivateKey != nil && subKey.PrivateKey.Encrypted {
+				if err := subKey.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+					return nil, fmt.Errorf("unable to decrypt gpg_secret_key subkey with the supplied gpg_passphrase: %s", err)
+				}
+			}
+		}
+	}
+
+	return entity, nil
+}
+
+// validateGPGSigningIdentity ensures that the given email address matches one
+// of the UIDs on the signing entity. GitHub will only show a commit or tag as
+// "Verified" if the committer/tagger email matches the key's identity, so we
+// fail fast with a clear error rather than letting GitHub silently create an
+// unverified commit.
+func validateGPGSigningIdentity(entity *openpgp.Entity, email string) error {
+	for _, identity := range entity.Identities {
+		if strings.EqualFold(identity.UserId.Email, email) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("commit_email (%s) does not match any UID on the gpg_secret_key; the commit would show as unverified", email)
+}
+
+// signGitObject produces an ASCII-armored detached PGP signature over the
+// canonical payload of a Git commit or tag object, suitable for use as the
+// `signature` of a github.Commit or github.Tag's Verification.
+func signGitObject(entity *openpgp.Entity, payload string) (string, error) {
+	var signature bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&signature, entity, strings.NewReader(payload), nil); err != nil {
+		return "", fmt.Errorf("unable to sign git object: %s", err)
+	}
+
+	return signature.String(), nil
+}
+
+// canonicalCommitPayload builds the exact byte sequence Git hashes and signs
+// for a commit object, per Git's signed commit format: the tree, parents,
+// author and committer lines, a blank line, and the commit message.
+func canonicalCommitPayload(tree string, parents []string, authorName, authorEmail string, authorTime time.Time, committerName, committerEmail string, committerTime time.Time, message string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "tree %s\n", tree)
+	for _, parent := range parents {
+		fmt.Fprintf(&b, "parent %s\n", parent)
+	}
+	fmt.Fprintf(&b, "author %s <%s> %s\n", authorName, authorEmail, gitTimestamp(authorTime))
+	fmt.Fprintf(&b, "committer %s <%s> %s\n", committerName, committerEmail, gitTimestamp(committerTime))
+	b.WriteString("\n")
+	b.WriteString(message)
+
+	return b.String()
+}
+
+// canonicalTagPayload builds the exact byte sequence Git hashes and signs for
+// an annotated tag object.
+func canonicalTagPayload(object, objectType, tag, taggerName, taggerEmail string, taggerTime time.Time, message string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "object %s\n", object)
+	fmt.Fprintf(&b, "type %s\n", objectType)
+	fmt.Fprintf(&b, "tag %s\n", tag)
+	fmt.Fprintf(&b, "tagger %s <%s> %s\n", taggerName, taggerEmail, gitTimestamp(taggerTime))
+	b.WriteString("\n")
+	b.WriteString(message)
+
+	return b.String()
+}
+
+// gitTimestamp formats a time.Time as Git expects it in a commit/tag object:
+// seconds since the epoch followed by the UTC offset, e.g. "1666000000 +0000".
+func gitTimestamp(t time.Time) string {
+	return fmt.Sprintf("%d %s", t.Unix(), t.Format("-0700"))
+}
@@ -0,0 +1,96 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCanonicalCommitPayload checks canonicalCommitPayload against a fixture
+// built by hand to match the raw object `git cat-file commit <sha>` would
+// print: this byte sequence is exactly what GitHub re-hashes and checks the
+// signature against, so any drift here (a missing newline, wrong timestamp
+// format, etc.) is the difference between a commit showing as "Verified"
+// and one silently rejected as unverified.
+func TestCanonicalCommitPayload(t *testing.T) {
+	epoch := time.Unix(0, 0).UTC()
+
+	got := canonicalCommitPayload(
+		"4b825dc642cb6eb9a060e54bf8d69288fbee4904",
+		[]string{"7638417db6d59f3c431d3e1f261cc637155684cd"},
+		"A U Thor", "author@example.com", epoch,
+		"A U Thor", "author@example.com", epoch,
+		"Test commit\n",
+	)
+
+	want := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"parent 7638417db6d59f3c431d3e1f261cc637155684cd\n" +
+		"author A U Thor <author@example.com> 0 +0000\n" +
+		"committer A U Thor <author@example.com> 0 +0000\n" +
+		"\n" +
+		"Test commit\n"
+
+	if got != want {
+		t.Fatalf("canonicalCommitPayload = %q, want %q", got, want)
+	}
+}
+
+// TestCanonicalCommitPayload_noParents checks the root-commit case, where no
+// "parent" line should be emitted at all.
+func TestCanonicalCommitPayload_noParents(t *testing.T) {
+	epoch := time.Unix(0, 0).UTC()
+
+	got := canonicalCommitPayload(
+		"4b825dc642cb6eb9a060e54bf8d69288fbee4904",
+		nil,
+		"A U Thor", "author@example.com", epoch,
+		"A U Thor", "author@example.com", epoch,
+		"Initial commit\n",
+	)
+
+	want := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author A U Thor <author@example.com> 0 +0000\n" +
+		"committer A U Thor <author@example.com> 0 +0000\n" +
+		"\n" +
+		"Initial commit\n"
+
+	if got != want {
+		t.Fatalf("canonicalCommitPayload = %q, want %q", got, want)
+	}
+}
+
+// TestCanonicalTagPayload checks canonicalTagPayload the same way, against
+// the raw object `git cat-file tag <sha>` would print for an annotated tag.
+func TestCanonicalTagPayload(t *testing.T) {
+	epoch := time.Unix(0, 0).UTC()
+
+	got := canonicalTagPayload(
+		"7638417db6d59f3c431d3e1f261cc637155684cd", "commit", "v1.0.0",
+		"A U Thor", "author@example.com", epoch,
+		"Release 1.0.0\n",
+	)
+
+	want := "object 7638417db6d59f3c431d3e1f261cc637155684cd\n" +
+		"type commit\n" +
+		"tag v1.0.0\n" +
+		"tagger A U Thor <author@example.com> 0 +0000\n" +
+		"\n" +
+		"Release 1.0.0\n"
+
+	if got != want {
+		t.Fatalf("canonicalTagPayload = %q, want %q", got, want)
+	}
+}
+
+// TestGitTimestamp pins down the offset formatting used by both payload
+// builders, since Git requires a fixed-width "+HHMM"/"-HHMM" offset rather
+// than Go's default zone formats.
+func TestGitTimestamp(t *testing.T) {
+	west := time.Date(2009, time.May, 24, 10, 9, 34, 0, time.FixedZone("", -7*60*60))
+	if got, want := gitTimestamp(west), "1243184974 -0700"; got != want {
+		t.Fatalf("gitTimestamp = %q, want %q", got, want)
+	}
+
+	if got, want := gitTimestamp(time.Unix(0, 0).UTC()), "0 +0000"; got != want {
+		t.Fatalf("gitTimestamp = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,119 @@
+package github
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"golang.org/x/oauth2"
+)
+
+// Provider returns the github Terraform provider, wiring up every resource
+// and the credentials/client configuration shared across them.
+func Provider() *schema.Provider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITHUB_TOKEN", nil),
+				Description: "The OAuth token used to connect to GitHub.",
+				Sensitive:   true,
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITHUB_OWNER", nil),
+				Description: "The GitHub owner name to manage. Use this field instead of `organization` when managing individual accounts.",
+			},
+			"organization": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITHUB_ORGANIZATION", nil),
+				Description: "The GitHub organization name to manage. Deprecated in favor of `owner`.",
+				Deprecated:  "Use owner instead of organization",
+			},
+			"base_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITHUB_BASE_URL", "https://api.github.com/"),
+				Description: "The GitHub Base API URL",
+			},
+			"insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITHUB_INSECURE", false),
+				Description: "Whether server should be accessed without verifying the TLS certificate.",
+			},
+			"gpg_secret_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITHUB_GPG_SECRET_KEY", nil),
+				Description: "An ASCII-armored PGP private key, used to GPG-sign commits and tags created by `github_repository_file`, `github_repository_files` and `github_repository_signed_tag`. Signing is disabled unless this is set. The commit `github_repository_files` makes to remove its files on destroy is not signed.",
+				Sensitive:   true,
+			},
+			"gpg_passphrase": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITHUB_GPG_PASSPHRASE", nil),
+				Description: "The passphrase protecting `gpg_secret_key`, if it is encrypted.",
+				Sensitive:   true,
+			},
+		},
+
+		ResourcesMap: map[string]*schema.Resource{
+			"github_membership":            resourceGithubMembership(),
+			"github_repository_deploy_key": resourceGithubRepositoryDeployKey(),
+			"github_repository_file":       resourceGithubRepositoryFile(),
+			"github_repository_files":      resourceGithubRepositoryFiles(),
+			"github_repository_signed_tag": resourceGithubRepositorySignedTag(),
+		},
+
+		ConfigureFunc: providerConfigure,
+	}
+}
+
+// Owner holds the configured GitHub client and the owner/organization that
+// resources in this provider operate against.
+type Owner struct {
+	v3client *github.Client
+	name     string
+
+	// gpgSecretKey and gpgPassphrase configure GPG-signed commits and tags.
+	// Left empty, resources fall back to their unsigned code paths; see
+	// gpgSigningEntity in resource_github_repository_file.go.
+	gpgSecretKey  string
+	gpgPassphrase string
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	owner := d.Get("owner").(string)
+	if owner == "" {
+		owner = d.Get("organization").(string)
+	}
+
+	ctx := context.Background()
+	tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: d.Get("token").(string)})
+	tc := oauth2.NewClient(ctx, tokenSource)
+
+	if d.Get("insecure").(bool) {
+		tc.Transport.(*oauth2.Transport).Base = &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+
+	baseURL := d.Get("base_url").(string)
+	v3client, err := github.NewEnterpriseClient(baseURL, baseURL, tc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Owner{
+		v3client:      v3client,
+		name:          owner,
+		gpgSecretKey:  d.Get("gpg_secret_key").(string),
+		gpgPassphrase: d.Get("gpg_passphrase").(string),
+	}, nil
+}
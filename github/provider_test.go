@@ -0,0 +1,59 @@
+package github
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+var testAccProviders map[string]terraform.ResourceProvider
+var testAccProvider *schema.Provider
+
+func init() {
+	testAccProvider = Provider().(*schema.Provider)
+	testAccProviders = map[string]terraform.ResourceProvider{
+		"github": testAccProvider,
+	}
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+// testAccPreCheck skips acceptance tests unless credentials for a real
+// GitHub account are available; these tests create and destroy real files,
+// commits, tags and deploy keys.
+func testAccPreCheck(t *testing.T) {
+	if os.Getenv("GITHUB_TOKEN") == "" {
+		t.Skip("GITHUB_TOKEN must be set for acceptance tests")
+	}
+	if os.Getenv("GITHUB_OWNER") == "" {
+		t.Skip("GITHUB_OWNER must be set for acceptance tests")
+	}
+
+	// Configure eagerly, from the same env vars ConfigureFunc reads, so that
+	// testAccProvider.Meta() is already usable by test helpers that call the
+	// API directly (e.g. to read a fixture repository's state) before the
+	// first resource.Test() apply configures it implicitly.
+	if testAccProvider.Meta() == nil {
+		if err := testAccProvider.Configure(terraform.NewResourceConfigRaw(nil)); err != nil {
+			t.Fatalf("unable to configure provider for acceptance tests: %s", err)
+		}
+	}
+}
+
+// testAccRepositoryName returns the name of an existing repository that
+// acceptance tests are allowed to write files, commits and tags to. This
+// provider has no `github_repository` resource to create one on the fly, so
+// tests operate against a fixture repository supplied out of band.
+func testAccRepositoryName(t *testing.T) string {
+	name := os.Getenv("GITHUB_TEST_REPOSITORY")
+	if name == "" {
+		t.Skip("GITHUB_TEST_REPOSITORY must be set to an existing repository these tests can write to")
+	}
+	return name
+}
@@ -2,13 +2,16 @@ package github
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v45/github"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 )
 
@@ -16,23 +19,28 @@ func resourceGithubRepositoryDeployKey() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceGithubRepositoryDeployKeyCreate,
 		Read:   resourceGithubRepositoryDeployKeyRead,
+		Update: resourceGithubRepositoryDeployKeyUpdate,
 		Delete: resourceGithubRepositoryDeployKeyDelete,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		CustomizeDiff: resourceGithubRepositoryDeployKeyCustomizeDiff,
 
-		// Deploy keys are defined immutable in the API. Updating results in force new.
+		// Deploy keys are immutable in the API. By default, changing any
+		// attribute forces replacement, which tears down the old key before
+		// the new one exists. Setting `rotate_on_change` switches to
+		// create-before-destroy semantics instead: see
+		// resourceGithubRepositoryDeployKeyCustomizeDiff and
+		// resourceGithubRepositoryDeployKeyUpdate.
 		Schema: map[string]*schema.Schema{
 			"key": {
 				Type:             schema.TypeString,
 				Required:         true,
-				ForceNew:         true,
 				DiffSuppressFunc: suppressDeployKeyDiff,
 			},
 			"read_only": {
 				Type:     schema.TypeBool,
 				Optional: true,
-				ForceNew: true,
 				Default:  true,
 			},
 			"repository": {
@@ -43,7 +51,23 @@ func resourceGithubRepositoryDeployKey() *schema.Resource {
 			"title": {
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
+			},
+			"rotate_on_change": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Upload the replacement key before deleting the previous one when `key`, `title` or `read_only` change, instead of forcing replacement",
+			},
+			"grace_period": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Number of seconds to keep the previous deploy key active after the replacement is uploaded, before it is deleted. Only used when `rotate_on_change` is `true`",
+			},
+			"previous_key_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the deploy key that was replaced by the most recent rotation, if any",
 			},
 			"etag": {
 				Type:     schema.TypeString,
@@ -53,6 +77,26 @@ func resourceGithubRepositoryDeployKey() *schema.Resource {
 	}
 }
 
+// resourceGithubRepositoryDeployKeyCustomizeDiff preserves the historical
+// force-new behavior for `key`/`title`/`read_only` changes unless the resource opts in
+// to `rotate_on_change`, in which case the Update function handles them in
+// place.
+func resourceGithubRepositoryDeployKeyCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Get("rotate_on_change").(bool) {
+		return nil
+	}
+
+	for _, key := range []string{"key", "title", "read_only"} {
+		if diff.HasChange(key) {
+			if err := diff.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func resourceGithubRepositoryDeployKeyCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Owner).v3client
 
@@ -80,6 +124,110 @@ func resourceGithubRepositoryDeployKeyCreate(d *schema.ResourceData, meta interf
 	return resourceGithubRepositoryDeployKeyRead(d, meta)
 }
 
+// resourceGithubRepositoryDeployKeyUpdate rotates a deploy key without
+// downtime: the replacement key is uploaded and confirmed listed before the
+// previous key, tracked in `previous_key_id`, is deleted. This only runs
+// when CustomizeDiff left `key`/`title`/`read_only` in place instead of forcing a new
+// resource, i.e. when `rotate_on_change` is `true`.
+func resourceGithubRepositoryDeployKeyUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.Background()
+
+	repoName, idString, err := parseTwoPartID(d.Id(), "repository", "ID")
+	if err != nil {
+		return err
+	}
+
+	attributesChanged := d.HasChange("key") || d.HasChange("title") || d.HasChange("read_only")
+
+	// A prior apply may have uploaded the replacement key and persisted it
+	// to state, then failed before the previous key was removed (wait
+	// timeout, delete error). Refuse to start another rotation on top of
+	// that, even if key/title/read_only changed again in the meantime:
+	// doing so would overwrite `previous_key_id` with the new stale key and
+	// permanently orphan the one still pending deletion. Resume the pending
+	// cleanup first; the attribute change will be picked up on the next
+	// apply once previous_key_id is clear again.
+	if previousKeyID, ok := d.GetOk("previous_key_id"); ok {
+		if attributesChanged {
+			return fmt.Errorf("deploy key %s has not finished rotating out key %s; re-apply to finish removing it before changing key, title or read_only again", d.Id(), previousKeyID.(string))
+		}
+		return resourceGithubRepositoryDeployKeyFinishRotation(ctx, d, meta, repoName, previousKeyID.(string))
+	}
+
+	if !attributesChanged {
+		return resourceGithubRepositoryDeployKeyRead(d, meta)
+	}
+
+	previousID, err := strconv.ParseInt(idString, 10, 64)
+	if err != nil {
+		return unconvertibleIdErr(idString, err)
+	}
+
+	key := d.Get("key").(string)
+	title := d.Get("title").(string)
+	readOnly := d.Get("read_only").(bool)
+
+	log.Printf("[DEBUG] Uploading replacement deploy key %q for %s/%s before removing %d", title, owner, repoName, previousID)
+	newKey, _, err := client.Repositories.CreateKey(ctx, owner, repoName, &github.Key{
+		Key:      github.String(key),
+		Title:    github.String(title),
+		ReadOnly: github.Bool(readOnly),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Persist the replacement right away. If the wait below times out, or
+	// the cleanup delete fails, the next apply sees the new key already in
+	// state and `previous_key_id` still set, and resumes via the branch
+	// above instead of creating a second replacement key.
+	d.SetId(buildTwoPartID(repoName, strconv.FormatInt(newKey.GetID(), 10)))
+	d.Set("previous_key_id", idString)
+
+	err = resource.Retry(2*time.Minute, func() *resource.RetryError {
+		if _, _, err := client.Repositories.GetKey(ctx, owner, repoName, newKey.GetID()); err != nil {
+			return resource.RetryableError(fmt.Errorf("waiting for replacement deploy key %d to be listed: %s", newKey.GetID(), err))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceGithubRepositoryDeployKeyFinishRotation(ctx, d, meta, repoName, idString)
+}
+
+// resourceGithubRepositoryDeployKeyFinishRotation holds the previous deploy
+// key for `grace_period` seconds and then removes it, clearing
+// `previous_key_id` once that succeeds.
+func resourceGithubRepositoryDeployKeyFinishRotation(ctx context.Context, d *schema.ResourceData, meta interface{}, repoName, previousKeyIDString string) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+
+	previousID, err := strconv.ParseInt(previousKeyIDString, 10, 64)
+	if err != nil {
+		return unconvertibleIdErr(previousKeyIDString, err)
+	}
+
+	if gracePeriod := d.Get("grace_period").(int); gracePeriod > 0 {
+		log.Printf("[DEBUG] Holding previous deploy key %d for %ds grace period", previousID, gracePeriod)
+		time.Sleep(time.Duration(gracePeriod) * time.Second)
+	}
+
+	log.Printf("[DEBUG] Deleting previous deploy key %d from %s/%s", previousID, owner, repoName)
+	if _, err := client.Repositories.DeleteKey(ctx, owner, repoName, previousID); err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); !ok || ghErr.Response.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("previous deploy key %d could not be removed: %s", previousID, err)
+		}
+	}
+
+	d.Set("previous_key_id", "")
+
+	return resourceGithubRepositoryDeployKeyRead(d, meta)
+}
+
 func resourceGithubRepositoryDeployKeyRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Owner).v3client
 
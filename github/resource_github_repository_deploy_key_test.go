@@ -0,0 +1,48 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+// TestAccGithubRepositoryDeployKey_rotate covers `rotate_on_change`: a
+// change to `title` should upload a replacement key, track the previous one
+// in `previous_key_id`, and end the apply with `previous_key_id` cleared
+// again once the old key is removed.
+func TestAccGithubRepositoryDeployKey_rotate(t *testing.T) {
+	repo := testAccRepositoryName(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGithubRepositoryDeployKeyRotateConfig(repo, "deploy key v1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("github_repository_deploy_key.test", "previous_key_id", ""),
+				),
+			},
+			{
+				Config: testAccGithubRepositoryDeployKeyRotateConfig(repo, "deploy key v2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("github_repository_deploy_key.test", "previous_key_id", ""),
+				),
+			},
+		},
+	})
+}
+
+func testAccGithubRepositoryDeployKeyRotateConfig(repo, title string) string {
+	return fmt.Sprintf(`
+resource "github_repository_deploy_key" "test" {
+  repository       = %q
+  title            = %q
+  key              = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIBMpsxYoxlGhtcFEMRDbUxvzh/XTzDb87YbUIlBhGOsj"
+  read_only        = true
+  rotate_on_change = true
+  grace_period     = 0
+}
+`, repo, title)
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"log"
 	"strings"
+	"time"
 
 	"fmt"
 
 	"github.com/google/go-github/v45/github"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"golang.org/x/crypto/openpgp"
 )
 
 func resourceGithubRepositoryFile() *schema.Resource {
@@ -104,6 +106,23 @@ func resourceGithubRepositoryFile() *schema.Resource {
 				Description: "Enable overwriting existing files, defaults to \"false\"",
 				Default:     false,
 			},
+			"bypass_pull_request": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Skip the branch protection / CODEOWNERS pre-flight check and push directly, for identities with bypass permission. Defaults to \"false\"",
+				Default:     false,
+			},
+			"require_pull_request": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Push the change to a new branch and open a pull request against `branch` instead of committing directly. Defaults to \"false\"",
+				Default:     false,
+			},
+			"head_branch": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The branch the pull request was opened from when `require_pull_request` is \"true\". Reused for subsequent applies as long as the pull request is still open",
+			},
 		},
 	}
 }
@@ -195,18 +214,250 @@ func resourceGithubRepositoryFileCreate(d *schema.ResourceData, meta interface{}
 		}
 	}
 
-	// Create a new or overwritten file
-	create, _, err := client.Repositories.CreateFile(ctx, owner, repo, file, opts)
+	requirePullRequest := d.Get("require_pull_request").(bool)
+
+	if !requirePullRequest && !d.Get("bypass_pull_request").(bool) {
+		if err := checkDirectPushAllowed(ctx, client, owner, repo, branch, file); err != nil {
+			return err
+		}
+	}
+
+	var commitSHA, headBranch string
+	if requirePullRequest {
+		commitSHA, headBranch, err = openRepositoryFilePullRequest(ctx, meta, owner, repo, branch, file, opts, d.Get("head_branch").(string))
+	} else {
+		commitSHA, err = createOrUpdateRepositoryFile(ctx, meta, owner, repo, branch, file, opts)
+	}
 	if err != nil {
 		return err
 	}
 
 	d.SetId(fmt.Sprintf("%s/%s", repo, file))
-	d.Set("commit_sha", create.Commit.GetSHA())
+	d.Set("commit_sha", commitSHA)
+	d.Set("head_branch", headBranch)
 
 	return resourceGithubRepositoryFileRead(d, meta)
 }
 
+// openRepositoryFilePullRequest applies a file change on a branch cut from
+// the current tip of `branch` and opens a pull request back into it, rather
+// than committing to `branch` directly. If a previous apply already opened
+// a pull request for this resource and it is still open, the existing head
+// branch is reused so repeated applies push new commits onto the same pull
+// request instead of failing with "reference already exists". It returns
+// the SHA of the commit pushed to the head branch and the head branch name,
+// which the caller persists in `head_branch` for the next Read/Update.
+func openRepositoryFilePullRequest(ctx context.Context, meta interface{}, owner, repo, branch, file string, opts *github.RepositoryContentFileOptions, previousHeadBranch string) (string, string, error) {
+	client := meta.(*Owner).v3client
+
+	headBranch := previousHeadBranch
+	reuseBranch := false
+	if headBranch != "" {
+		if _, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+headBranch); err == nil {
+			open, _, err := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+				State: "open",
+				Head:  owner + ":" + headBranch,
+				Base:  branch,
+			})
+			reuseBranch = err == nil && len(open) > 0
+		}
+	}
+
+	if !reuseBranch {
+		ref, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+		if err != nil {
+			return "", "", fmt.Errorf("unable to get ref for branch %s: %s", branch, err)
+		}
+
+		headBranch = fmt.Sprintf("terraform-%s-%d", strings.ReplaceAll(file, "/", "-"), time.Now().UnixNano())
+		if _, _, err := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+			Ref:    github.String("refs/heads/" + headBranch),
+			Object: ref.Object,
+		}); err != nil {
+			return "", "", fmt.Errorf("unable to create branch %s for pull request: %s", headBranch, err)
+		}
+	}
+
+	prOpts := *opts
+	prOpts.Branch = github.String(headBranch)
+
+	if reuseBranch {
+		// The file's SHA on the base branch is stale once the head branch
+		// has its own commit; look up the current one so CreateFile's
+		// optimistic-concurrency check doesn't reject the update.
+		current, _, _, err := client.Repositories.GetContents(ctx, owner, repo, file, &github.RepositoryContentGetOptions{Ref: headBranch})
+		if err != nil {
+			return "", "", fmt.Errorf("unable to read %s from existing pull request branch %s: %s", file, headBranch, err)
+		}
+		if current != nil {
+			prOpts.SHA = current.SHA
+		}
+	}
+
+	commitSHA, err := createOrUpdateRepositoryFile(ctx, meta, owner, repo, headBranch, file, &prOpts)
+	if err != nil {
+		return "", "", err
+	}
+
+	if !reuseBranch {
+		if _, _, err := client.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+			Title: github.String(*opts.Message),
+			Head:  github.String(headBranch),
+			Base:  github.String(branch),
+			Body:  github.String(fmt.Sprintf("Updates `%s` via Terraform.", file)),
+		}); err != nil {
+			return "", "", fmt.Errorf("unable to open pull request from %s into %s: %s", headBranch, branch, err)
+		}
+	}
+
+	return commitSHA, headBranch, nil
+}
+
+// createOrUpdateRepositoryFile writes a single file to a repository branch,
+// returning the SHA of the commit that was created. When the provider is
+// configured with a `gpg_secret_key`, the commit is built and signed via the
+// low-level Git Data API so that it shows up as "Verified" on GitHub;
+// otherwise it falls back to the plain contents API used historically by
+// this resource.
+func createOrUpdateRepositoryFile(ctx context.Context, meta interface{}, owner, repo, branch, file string, opts *github.RepositoryContentFileOptions) (string, error) {
+	entity, signingEnabled, err := gpgSigningEntity(meta)
+	if err != nil {
+		return "", err
+	}
+
+	if !signingEnabled {
+		client := meta.(*Owner).v3client
+		create, _, err := client.Repositories.CreateFile(ctx, owner, repo, file, opts)
+		if err != nil {
+			return "", err
+		}
+		return create.Commit.GetSHA(), nil
+	}
+
+	author, email := commitAuthorAndEmail(opts)
+	if author == "" || email == "" {
+		return "", fmt.Errorf("commit_author and commit_email must be set when gpg_secret_key is configured")
+	}
+
+	if err := validateGPGSigningIdentity(entity, email); err != nil {
+		return "", err
+	}
+
+	commit, err := createSignedBlobCommit(ctx, meta, entity, owner, repo, branch, file, opts.Content, *opts.Message, author, email)
+	if err != nil {
+		return "", err
+	}
+
+	return commit.GetSHA(), nil
+}
+
+// commitAuthorAndEmail returns the author name and email that will be used
+// to sign a commit, or two empty strings when the resource does not set
+// `commit_author`/`commit_email` explicitly.
+func commitAuthorAndEmail(opts *github.RepositoryContentFileOptions) (string, string) {
+	if opts.Author != nil {
+		return opts.Author.GetName(), opts.Author.GetEmail()
+	}
+
+	return "", ""
+}
+
+// gpgSigningEntity loads and decrypts the provider's configured GPG signing
+// key, if any. The second return value is false when no `gpg_secret_key` was
+// configured, in which case callers should fall back to unsigned commits.
+func gpgSigningEntity(meta interface{}) (*openpgp.Entity, bool, error) {
+	owner := meta.(*Owner)
+	if owner.gpgSecretKey == "" {
+		return nil, false, nil
+	}
+
+	entity, err := loadGPGSigningEntity(owner.gpgSecretKey, owner.gpgPassphrase)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return entity, true, nil
+}
+
+// createSignedBlobCommit drives the Git Data API directly: it fetches the
+// branch ref, reads the base tree, creates a blob for the new file content,
+// builds a new tree on top of the base tree, signs the resulting commit
+// object with the provider's GPG key, creates the commit and fast-forwards
+// the branch ref to point at it.
+func createSignedBlobCommit(ctx context.Context, meta interface{}, entity *openpgp.Entity, owner, repo, branch, file string, content []byte, message, authorName, authorEmail string) (*github.Commit, error) {
+	client := meta.(*Owner).v3client
+
+	ref, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get ref for branch %s: %s", branch, err)
+	}
+	parentSHA := ref.GetObject().GetSHA()
+
+	parentCommit, _, err := client.Git.GetCommit(ctx, owner, repo, parentSHA)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get parent commit %s: %s", parentSHA, err)
+	}
+
+	blob, _, err := client.Git.CreateBlob(ctx, owner, repo, &github.Blob{
+		Content:  github.String(string(content)),
+		Encoding: github.String("utf-8"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create blob for %s: %s", file, err)
+	}
+
+	tree, _, err := client.Git.CreateTree(ctx, owner, repo, parentCommit.GetTree().GetSHA(), []*github.TreeEntry{
+		{
+			Path: github.String(file),
+			Mode: github.String("100644"),
+			Type: github.String("blob"),
+			SHA:  blob.SHA,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create tree for %s: %s", file, err)
+	}
+
+	now := time.Now()
+	payload := canonicalCommitPayload(tree.GetSHA(), []string{parentSHA}, authorName, authorEmail, now, authorName, authorEmail, now, message)
+
+	signature, err := signGitObject(entity, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, _, err := client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+		Message: github.String(message),
+		Tree:    tree,
+		Parents: []*github.Commit{{SHA: github.String(parentSHA)}},
+		Author: &github.CommitAuthor{
+			Name:  github.String(authorName),
+			Email: github.String(authorEmail),
+			Date:  &now,
+		},
+		Committer: &github.CommitAuthor{
+			Name:  github.String(authorName),
+			Email: github.String(authorEmail),
+			Date:  &now,
+		},
+		Verification: &github.SignatureVerification{
+			Signature: github.String(signature),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create signed commit for %s: %s", file, err)
+	}
+
+	if _, _, err := client.Git.UpdateRef(ctx, owner, repo, &github.Reference{
+		Ref:    github.String("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: commit.SHA},
+	}, false); err != nil {
+		return nil, fmt.Errorf("unable to move branch %s to signed commit %s: %s", branch, commit.GetSHA(), err)
+	}
+
+	return commit, nil
+}
+
 func resourceGithubRepositoryFileRead(d *schema.ResourceData, meta interface{}) error {
 
 	client := meta.(*Owner).v3client
@@ -220,7 +471,22 @@ func resourceGithubRepositoryFileRead(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
-	opts := &github.RepositoryContentGetOptions{Ref: branch}
+	// When `require_pull_request` is in effect, the latest applied content
+	// lives on the pull request's head branch, not `branch`, until the pull
+	// request is merged. Read from there while the head branch still
+	// exists; once it's gone (e.g. merged and auto-deleted) fall back to
+	// `branch`, which by then should hold the merged content.
+	readBranch := branch
+	headBranch := d.Get("head_branch").(string)
+	if d.Get("require_pull_request").(bool) && headBranch != "" {
+		if _, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+headBranch); err == nil {
+			readBranch = headBranch
+		} else {
+			d.Set("head_branch", "")
+		}
+	}
+
+	opts := &github.RepositoryContentGetOptions{Ref: readBranch}
 	fc, _, _, _ := client.Repositories.GetContents(ctx, owner, repo, file, opts)
 	if fc == nil {
 		log.Printf("[INFO] Removing repository path %s/%s/%s from state because it no longer exists in GitHub",
@@ -286,12 +552,26 @@ func resourceGithubRepositoryFileUpdate(d *schema.ResourceData, meta interface{}
 		opts.Message = &m
 	}
 
-	create, _, err := client.Repositories.CreateFile(ctx, owner, repo, file, opts)
+	requirePullRequest := d.Get("require_pull_request").(bool)
+
+	if !requirePullRequest && !d.Get("bypass_pull_request").(bool) {
+		if err := checkDirectPushAllowed(ctx, client, owner, repo, branch, file); err != nil {
+			return err
+		}
+	}
+
+	var commitSHA, headBranch string
+	if requirePullRequest {
+		commitSHA, headBranch, err = openRepositoryFilePullRequest(ctx, meta, owner, repo, branch, file, opts, d.Get("head_branch").(string))
+	} else {
+		commitSHA, err = createOrUpdateRepositoryFile(ctx, meta, owner, repo, branch, file, opts)
+	}
 	if err != nil {
 		return err
 	}
 
-	d.Set("commit_sha", create.GetSHA())
+	d.Set("commit_sha", commitSHA)
+	d.Set("head_branch", headBranch)
 
 	return resourceGithubRepositoryFileRead(d, meta)
 }
@@ -306,6 +586,41 @@ func resourceGithubRepositoryFileDelete(d *schema.ResourceData, meta interface{}
 	file := d.Get("file").(string)
 	branch := d.Get("branch").(string)
 
+	requirePullRequest := d.Get("require_pull_request").(bool)
+	if requirePullRequest {
+		return fmt.Errorf("require_pull_request is not supported when deleting %s; set it to false and remove the file through a reviewed commit first", file)
+	}
+
+	if !d.Get("bypass_pull_request").(bool) {
+		if err := checkDirectPushAllowed(ctx, client, owner, repo, branch, file); err != nil {
+			return err
+		}
+	}
+
+	entity, signingEnabled, err := gpgSigningEntity(meta)
+	if err != nil {
+		return err
+	}
+
+	if signingEnabled {
+		author := d.Get("commit_author").(string)
+		email := d.Get("commit_email").(string)
+		if author == "" || email == "" {
+			return fmt.Errorf("commit_author and commit_email must be set when gpg_secret_key is configured")
+		}
+
+		if err := validateGPGSigningIdentity(entity, email); err != nil {
+			return err
+		}
+
+		message := fmt.Sprintf("Delete %s", file)
+		if _, err := deleteSignedBlobCommit(ctx, meta, entity, owner, repo, branch, file, message, author, email); err != nil {
+			return err
+		}
+
+		return nil
+	}
+
 	message := fmt.Sprintf("Delete %s", file)
 	sha := d.Get("sha").(string)
 	opts := &github.RepositoryContentFileOptions{
@@ -314,10 +629,91 @@ func resourceGithubRepositoryFileDelete(d *schema.ResourceData, meta interface{}
 		Branch:  &branch,
 	}
 
-	_, _, err := client.Repositories.DeleteFile(ctx, owner, repo, file, opts)
+	_, _, err = client.Repositories.DeleteFile(ctx, owner, repo, file, opts)
 	if err != nil {
 		return nil
 	}
 
 	return nil
 }
+
+// deleteSignedBlobCommit removes a single file from the tree and creates a
+// new signed commit that no longer contains it. Deletions cannot be
+// expressed via `base_tree` alone, so the full tree is read recursively and
+// rebuilt without the deleted entry.
+func deleteSignedBlobCommit(ctx context.Context, meta interface{}, entity *openpgp.Entity, owner, repo, branch, file, message, authorName, authorEmail string) (*github.Commit, error) {
+	client := meta.(*Owner).v3client
+
+	ref, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get ref for branch %s: %s", branch, err)
+	}
+	parentSHA := ref.GetObject().GetSHA()
+
+	parentCommit, _, err := client.Git.GetCommit(ctx, owner, repo, parentSHA)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get parent commit %s: %s", parentSHA, err)
+	}
+
+	baseTree, _, err := client.Git.GetTree(ctx, owner, repo, parentCommit.GetTree().GetSHA(), true)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get tree %s: %s", parentCommit.GetTree().GetSHA(), err)
+	}
+
+	entries := make([]*github.TreeEntry, 0, len(baseTree.Entries))
+	for _, entry := range baseTree.Entries {
+		if entry.GetType() == "tree" || entry.GetPath() == file {
+			continue
+		}
+		entries = append(entries, &github.TreeEntry{
+			Path: entry.Path,
+			Mode: entry.Mode,
+			Type: entry.Type,
+			SHA:  entry.SHA,
+		})
+	}
+
+	tree, _, err := client.Git.CreateTree(ctx, owner, repo, "", entries)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create tree without %s: %s", file, err)
+	}
+
+	now := time.Now()
+	payload := canonicalCommitPayload(tree.GetSHA(), []string{parentSHA}, authorName, authorEmail, now, authorName, authorEmail, now, message)
+
+	signature, err := signGitObject(entity, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, _, err := client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+		Message: github.String(message),
+		Tree:    tree,
+		Parents: []*github.Commit{{SHA: github.String(parentSHA)}},
+		Author: &github.CommitAuthor{
+			Name:  github.String(authorName),
+			Email: github.String(authorEmail),
+			Date:  &now,
+		},
+		Committer: &github.CommitAuthor{
+			Name:  github.String(authorName),
+			Email: github.String(authorEmail),
+			Date:  &now,
+		},
+		Verification: &github.SignatureVerification{
+			Signature: github.String(signature),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create signed commit deleting %s: %s", file, err)
+	}
+
+	if _, _, err := client.Git.UpdateRef(ctx, owner, repo, &github.Reference{
+		Ref:    github.String("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: commit.SHA},
+	}, false); err != nil {
+		return nil, fmt.Errorf("unable to move branch %s to signed commit %s: %s", branch, commit.GetSHA(), err)
+	}
+
+	return commit, nil
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+// TestAccGithubRepositoryFile_gpgSigned exercises the signed-commit path
+// added to this resource: with a `gpg_secret_key` configured on the
+// provider, creating a file should produce a commit GitHub reports as
+// "Verified" rather than going through the plain contents API.
+func TestAccGithubRepositoryFile_gpgSigned(t *testing.T) {
+	if os.Getenv("GITHUB_GPG_SECRET_KEY") == "" {
+		t.Skip("GITHUB_GPG_SECRET_KEY must be set to exercise GPG-signed commits")
+	}
+
+	repo := testAccRepositoryName(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGithubRepositoryFileGpgSignedConfig(repo),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("github_repository_file.test", "commit_sha"),
+					resource.TestCheckResourceAttr("github_repository_file.test", "commit_author", "Terraform Acceptance Tests"),
+					resource.TestCheckResourceAttr("github_repository_file.test", "commit_email", "terraform@example.com"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccGithubRepositoryFile_requirePullRequest covers opening a pull
+// request instead of committing directly, and reusing the same head branch
+// across a second apply instead of failing with "reference already exists".
+func TestAccGithubRepositoryFile_requirePullRequest(t *testing.T) {
+	repo := testAccRepositoryName(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGithubRepositoryFileRequirePullRequestConfig(repo, "first version\n"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("github_repository_file.test", "head_branch"),
+				),
+			},
+			{
+				Config: testAccGithubRepositoryFileRequirePullRequestConfig(repo, "second version\n"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("github_repository_file.test", "head_branch"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGithubRepositoryFileRequirePullRequestConfig(repo, content string) string {
+	return fmt.Sprintf(`
+resource "github_repository_file" "test" {
+  repository           = %q
+  branch                = "main"
+  file                  = "via-pull-request.txt"
+  content               = %q
+  commit_message        = "Update via-pull-request.txt"
+  require_pull_request  = true
+}
+`, repo, content)
+}
+
+func testAccGithubRepositoryFileGpgSignedConfig(repo string) string {
+	return fmt.Sprintf(`
+resource "github_repository_file" "test" {
+  repository     = %q
+  branch         = "main"
+  file           = "gpg-signed.txt"
+  content        = "signed via terraform\n"
+  commit_message = "Add gpg-signed.txt"
+  commit_author  = "Terraform Acceptance Tests"
+  commit_email   = "terraform@example.com"
+  bypass_pull_request = true
+}
+`, repo)
+}
@@ -0,0 +1,439 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceGithubRepositoryFiles() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubRepositoryFilesCreate,
+		Read:   resourceGithubRepositoryFilesRead,
+		Update: resourceGithubRepositoryFilesUpdate,
+		Delete: resourceGithubRepositoryFilesDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The repository name",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The branch name, defaults to \"main\"",
+				Default:     "main",
+			},
+			"commit_message": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The commit message when committing changes to the listed files",
+				Default:     "Update multiple files",
+			},
+			"commit_author": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The commit author name, defaults to the authenticated user's name",
+			},
+			"commit_email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The commit author email address, defaults to the authenticated user's email address",
+			},
+			"commit_sha": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA of the commit that applied the file actions",
+			},
+			"file": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "A file create/update/delete action to include in the commit",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "update",
+							ValidateFunc: validateValueFunc([]string{"create", "update", "delete"}),
+							Description:  "The action to perform for this file: \"create\", \"update\" or \"delete\", defaults to \"update\"",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The file path to manage",
+						},
+						"content": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The file's content. Required unless action is \"delete\"",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type repositoryFileAction struct {
+	action  string
+	path    string
+	content string
+}
+
+func expandRepositoryFileActions(d *schema.ResourceData) ([]repositoryFileAction, error) {
+	raw := d.Get("file").([]interface{})
+	actions := make([]repositoryFileAction, 0, len(raw))
+
+	for _, v := range raw {
+		m := v.(map[string]interface{})
+		action := repositoryFileAction{
+			action:  m["action"].(string),
+			path:    m["path"].(string),
+			content: m["content"].(string),
+		}
+
+		if action.action != "delete" && action.content == "" {
+			return nil, fmt.Errorf("file %q: content is required unless action is \"delete\"", action.path)
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+func resourceGithubRepositoryFilesCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.Background()
+
+	repo := d.Get("repository").(string)
+	branch := d.Get("branch").(string)
+
+	if err := checkRepositoryBranchExists(client, owner, repo, branch); err != nil {
+		return err
+	}
+
+	commitSHA, err := resourceGithubRepositoryFilesApply(ctx, d, meta, owner, repo, branch)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", repo, branch))
+	d.Set("commit_sha", commitSHA)
+
+	return resourceGithubRepositoryFilesRead(d, meta)
+}
+
+func resourceGithubRepositoryFilesUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.Background()
+
+	repo := d.Get("repository").(string)
+	branch := d.Get("branch").(string)
+
+	if err := checkRepositoryBranchExists(client, owner, repo, branch); err != nil {
+		return err
+	}
+
+	commitSHA, err := resourceGithubRepositoryFilesApply(ctx, d, meta, owner, repo, branch)
+	if err != nil {
+		return err
+	}
+
+	d.Set("commit_sha", commitSHA)
+
+	return resourceGithubRepositoryFilesRead(d, meta)
+}
+
+// resourceGithubRepositoryFilesApply drives the Git Data API directly since
+// the REST contents API only accepts one file per commit: it reads the
+// branch's current tree, uploads a blob per create/update action, assembles
+// a new tree that includes those entries and omits any delete actions,
+// commits it on top of the previous head and fast-forwards the branch.
+func resourceGithubRepositoryFilesApply(ctx context.Context, d *schema.ResourceData, meta interface{}, owner, repo, branch string) (string, error) {
+	client := meta.(*Owner).v3client
+
+	actions, err := expandRepositoryFileActions(d)
+	if err != nil {
+		return "", err
+	}
+
+	ref, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+	if err != nil {
+		return "", fmt.Errorf("unable to get ref for branch %s: %s", branch, err)
+	}
+	parentSHA := ref.GetObject().GetSHA()
+
+	parentCommit, _, err := client.Git.GetCommit(ctx, owner, repo, parentSHA)
+	if err != nil {
+		return "", fmt.Errorf("unable to get parent commit %s: %s", parentSHA, err)
+	}
+	baseTreeSHA := parentCommit.GetTree().GetSHA()
+
+	deletions := make(map[string]bool)
+	touched := make(map[string]bool)
+	entries := make([]*github.TreeEntry, 0, len(actions))
+
+	for _, action := range actions {
+		switch action.action {
+		case "delete":
+			deletions[action.path] = true
+		case "create", "update":
+			blob, _, err := client.Git.CreateBlob(ctx, owner, repo, &github.Blob{
+				Content:  github.String(action.content),
+				Encoding: github.String("utf-8"),
+			})
+			if err != nil {
+				return "", fmt.Errorf("unable to create blob for %s: %s", action.path, err)
+			}
+
+			entries = append(entries, &github.TreeEntry{
+				Path: github.String(action.path),
+				Mode: github.String("100644"),
+				Type: github.String("blob"),
+				SHA:  blob.SHA,
+			})
+			touched[action.path] = true
+		}
+	}
+
+	newTreeSHA := baseTreeSHA
+	if len(deletions) > 0 {
+		// A `base_tree` cannot express removing an entry, so the full tree
+		// is read recursively and rebuilt without the deleted paths. Paths
+		// already added above from a create/update action are skipped too,
+		// otherwise they would be re-added here with their stale base-tree
+		// SHA alongside the new entry already in `entries`.
+		baseTree, _, err := client.Git.GetTree(ctx, owner, repo, baseTreeSHA, true)
+		if err != nil {
+			return "", fmt.Errorf("unable to get tree %s: %s", baseTreeSHA, err)
+		}
+
+		for _, entry := range baseTree.Entries {
+			if entry.GetType() == "tree" || deletions[entry.GetPath()] || touched[entry.GetPath()] {
+				continue
+			}
+			entries = append(entries, &github.TreeEntry{
+				Path: entry.Path,
+				Mode: entry.Mode,
+				Type: entry.Type,
+				SHA:  entry.SHA,
+			})
+		}
+
+		newTreeSHA = ""
+	}
+
+	tree, _, err := client.Git.CreateTree(ctx, owner, repo, newTreeSHA, entries)
+	if err != nil {
+		return "", fmt.Errorf("unable to create tree: %s", err)
+	}
+
+	message := d.Get("commit_message").(string)
+	authorName := d.Get("commit_author").(string)
+	authorEmail := d.Get("commit_email").(string)
+
+	author := &github.CommitAuthor{}
+	if authorName != "" {
+		author.Name = github.String(authorName)
+	}
+	if authorEmail != "" {
+		author.Email = github.String(authorEmail)
+	}
+
+	commit := &github.Commit{
+		Message: github.String(message),
+		Tree:    tree,
+		Parents: []*github.Commit{{SHA: github.String(parentSHA)}},
+	}
+	if authorName != "" && authorEmail != "" {
+		commit.Author = author
+		commit.Committer = author
+	}
+
+	entity, signingEnabled, err := gpgSigningEntity(meta)
+	if err != nil {
+		return "", err
+	}
+
+	if signingEnabled {
+		if authorName == "" || authorEmail == "" {
+			return "", fmt.Errorf("commit_author and commit_email must be set when gpg_secret_key is configured")
+		}
+		if err := validateGPGSigningIdentity(entity, authorEmail); err != nil {
+			return "", err
+		}
+
+		now := time.Now()
+		payload := canonicalCommitPayload(tree.GetSHA(), []string{parentSHA}, authorName, authorEmail, now, authorName, authorEmail, now, message)
+		signature, err := signGitObject(entity, payload)
+		if err != nil {
+			return "", err
+		}
+
+		commit.Author.Date = &now
+		commit.Committer.Date = &now
+		commit.Verification = &github.SignatureVerification{Signature: github.String(signature)}
+	}
+
+	newCommit, _, err := client.Git.CreateCommit(ctx, owner, repo, commit)
+	if err != nil {
+		return "", fmt.Errorf("unable to create commit: %s", err)
+	}
+
+	if _, _, err := client.Git.UpdateRef(ctx, owner, repo, &github.Reference{
+		Ref:    github.String("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: newCommit.SHA},
+	}, false); err != nil {
+		return "", fmt.Errorf("unable to move branch %s to commit %s: %s", branch, newCommit.GetSHA(), err)
+	}
+
+	return newCommit.GetSHA(), nil
+}
+
+func resourceGithubRepositoryFilesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	repo, branch := splitRepoFilePath(d.Id())
+
+	if err := checkRepositoryBranchExists(client, owner, repo, branch); err != nil {
+		log.Printf("[INFO] Removing repository files %s from state because branch %s no longer exists", d.Id(), branch)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("repository", repo)
+	d.Set("branch", branch)
+
+	if sha, ok := d.GetOk("commit_sha"); ok {
+		commit, _, err := client.Repositories.GetCommit(ctx, owner, repo, sha.(string), nil)
+		if err != nil {
+			return err
+		}
+		d.Set("commit_author", commit.Commit.GetCommitter().GetName())
+		d.Set("commit_email", commit.Commit.GetCommitter().GetEmail())
+	}
+
+	return nil
+}
+
+// resourceGithubRepositoryFilesDelete removes every file in the last-applied
+// `file` list from the branch in a single commit, the same way
+// `github_repository_file`'s Delete removes the one file it manages. Files
+// whose last-applied action was already "delete" are left out since they are
+// not expected to still be present.
+func resourceGithubRepositoryFilesDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.Background()
+
+	repo := d.Get("repository").(string)
+	branch := d.Get("branch").(string)
+
+	if err := checkRepositoryBranchExists(client, owner, repo, branch); err != nil {
+		// The branch is already gone, so there is nothing left to remove.
+		return nil
+	}
+
+	actions, err := expandRepositoryFileActions(d)
+	if err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(actions))
+	for _, action := range actions {
+		if action.action != "delete" {
+			paths = append(paths, action.path)
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil
+	}
+
+	_, err = resourceGithubRepositoryFilesApplyDeletion(ctx, meta, owner, repo, branch, paths)
+	return err
+}
+
+// resourceGithubRepositoryFilesApplyDeletion removes the given paths from
+// the branch in a single commit, mirroring the tree-rebuild approach in
+// resourceGithubRepositoryFilesApply but without any create/update blobs.
+// The commit is not GPG-signed, even when `gpg_secret_key` is configured.
+func resourceGithubRepositoryFilesApplyDeletion(ctx context.Context, meta interface{}, owner, repo, branch string, paths []string) (string, error) {
+	client := meta.(*Owner).v3client
+
+	ref, _, err := client.Git.GetRef(ctx, owner, repo, "refs/heads/"+branch)
+	if err != nil {
+		return "", fmt.Errorf("unable to get ref for branch %s: %s", branch, err)
+	}
+	parentSHA := ref.GetObject().GetSHA()
+
+	parentCommit, _, err := client.Git.GetCommit(ctx, owner, repo, parentSHA)
+	if err != nil {
+		return "", fmt.Errorf("unable to get parent commit %s: %s", parentSHA, err)
+	}
+
+	deletions := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		deletions[path] = true
+	}
+
+	baseTree, _, err := client.Git.GetTree(ctx, owner, repo, parentCommit.GetTree().GetSHA(), true)
+	if err != nil {
+		return "", fmt.Errorf("unable to get tree %s: %s", parentCommit.GetTree().GetSHA(), err)
+	}
+
+	entries := make([]*github.TreeEntry, 0, len(baseTree.Entries))
+	for _, entry := range baseTree.Entries {
+		if entry.GetType() == "tree" || deletions[entry.GetPath()] {
+			continue
+		}
+		entries = append(entries, &github.TreeEntry{
+			Path: entry.Path,
+			Mode: entry.Mode,
+			Type: entry.Type,
+			SHA:  entry.SHA,
+		})
+	}
+
+	tree, _, err := client.Git.CreateTree(ctx, owner, repo, "", entries)
+	if err != nil {
+		return "", fmt.Errorf("unable to create tree: %s", err)
+	}
+
+	commit, _, err := client.Git.CreateCommit(ctx, owner, repo, &github.Commit{
+		Message: github.String("Delete files managed by terraform"),
+		Tree:    tree,
+		Parents: []*github.Commit{{SHA: github.String(parentSHA)}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to create commit: %s", err)
+	}
+
+	if _, _, err := client.Git.UpdateRef(ctx, owner, repo, &github.Reference{
+		Ref:    github.String("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: commit.SHA},
+	}, false); err != nil {
+		return "", fmt.Errorf("unable to move branch %s to commit %s: %s", branch, commit.GetSHA(), err)
+	}
+
+	return commit.GetSHA(), nil
+}
@@ -0,0 +1,99 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+// TestAccGithubRepositoryFiles_updateAndDelete covers the bug where mixing
+// an "update" action with a "delete" action in the same apply duplicated the
+// updated path in the new tree, once with its new blob SHA and once with
+// the stale base-tree SHA.
+func TestAccGithubRepositoryFiles_updateAndDelete(t *testing.T) {
+	repo := testAccRepositoryName(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckGithubRepositoryFilesDestroy(repo, []string{"kept.txt", "updated.txt"}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGithubRepositoryFilesConfig(repo, `
+  file {
+    path    = "kept.txt"
+    content = "unchanged\n"
+  }
+
+  file {
+    path    = "updated.txt"
+    content = "first version\n"
+  }
+
+  file {
+    path    = "removed.txt"
+    content = "will be deleted\n"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("github_repository_files.test", "commit_sha"),
+				),
+			},
+			{
+				Config: testAccGithubRepositoryFilesConfig(repo, `
+  file {
+    path    = "kept.txt"
+    content = "unchanged\n"
+  }
+
+  file {
+    path    = "updated.txt"
+    content = "second version\n"
+  }
+
+  file {
+    action  = "delete"
+    path    = "removed.txt"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("github_repository_files.test", "commit_sha"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGithubRepositoryFilesConfig(repo, files string) string {
+	return fmt.Sprintf(`
+resource "github_repository_files" "test" {
+  repository = %q
+  branch     = "main"
+
+%s
+}
+`, repo, files)
+}
+
+// testAccCheckGithubRepositoryFilesDestroy confirms that destroying the
+// resource actually removes the files it managed from the branch, rather
+// than leaving them in place.
+func testAccCheckGithubRepositoryFilesDestroy(repo string, paths []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*Owner).v3client
+		owner := testAccProvider.Meta().(*Owner).name
+		ctx := context.Background()
+
+		for _, path := range paths {
+			if _, _, _, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: "main"}); err == nil {
+				return fmt.Errorf("file %q still exists on %s after destroy", path, repo)
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,228 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func resourceGithubRepositorySignedTag() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGithubRepositorySignedTagCreate,
+		Read:   resourceGithubRepositorySignedTagRead,
+		Update: schema.Noop,
+		Delete: resourceGithubRepositorySignedTagDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The repository name",
+			},
+			"tag": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the tag, e.g. \"v1.0.0\"",
+			},
+			"object_sha": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The SHA of the commit the tag points at",
+			},
+			"message": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The tag message",
+			},
+			"tagger_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the tagger, defaults to the authenticated user's name",
+			},
+			"tagger_email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The email address of the tagger, defaults to the authenticated user's email address",
+			},
+			"force": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Point the existing `refs/tags/<tag>` ref at the new tag object if it already exists, instead of failing, defaults to \"false\"",
+			},
+			"sha": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA of the created tag object",
+			},
+		},
+	}
+}
+
+func resourceGithubRepositorySignedTagCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.Background()
+
+	repo := d.Get("repository").(string)
+	tag := d.Get("tag").(string)
+	objectSHA := d.Get("object_sha").(string)
+	message := d.Get("message").(string)
+	taggerName := d.Get("tagger_name").(string)
+	taggerEmail := d.Get("tagger_email").(string)
+
+	entity, signingEnabled, err := gpgSigningEntity(meta)
+	if err != nil {
+		return err
+	}
+	if !signingEnabled {
+		return fmt.Errorf("github_repository_signed_tag requires the provider to be configured with a gpg_secret_key")
+	}
+
+	if taggerName == "" || taggerEmail == "" {
+		authenticatedUser, _, err := client.Users.Get(ctx, "")
+		if err != nil {
+			return fmt.Errorf("unable to look up the authenticated user to default tagger_name/tagger_email: %s", err)
+		}
+		if taggerName == "" {
+			taggerName = authenticatedUser.GetName()
+		}
+		if taggerEmail == "" {
+			taggerEmail = authenticatedUser.GetEmail()
+		}
+	}
+
+	if taggerName == "" || taggerEmail == "" {
+		return fmt.Errorf("tagger_name and tagger_email could not be defaulted from the authenticated user; set them explicitly")
+	}
+
+	if err := validateGPGSigningIdentity(entity, taggerEmail); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	payload := canonicalTagPayload(objectSHA, "commit", tag, taggerName, taggerEmail, now, message)
+
+	signature, err := signGitObject(entity, payload)
+	if err != nil {
+		return err
+	}
+
+	newTag, _, err := client.Git.CreateTag(ctx, owner, repo, &github.Tag{
+		Tag:     github.String(tag),
+		Message: github.String(message),
+		Object: &github.GitObject{
+			Type: github.String("commit"),
+			SHA:  github.String(objectSHA),
+		},
+		Tagger: &github.CommitAuthor{
+			Name:  github.String(taggerName),
+			Email: github.String(taggerEmail),
+			Date:  &now,
+		},
+		Verification: &github.SignatureVerification{
+			Signature: github.String(signature),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create signed tag object for %s: %s", tag, err)
+	}
+
+	ref := "refs/tags/" + tag
+	_, resp, err := client.Git.GetRef(ctx, owner, repo, ref)
+	switch {
+	case err == nil && d.Get("force").(bool):
+		// Update the existing ref in place rather than deleting and
+		// recreating it, so a failed update leaves the previous tag intact
+		// instead of leaving the ref missing.
+		if _, _, err := client.Git.UpdateRef(ctx, owner, repo, &github.Reference{
+			Ref:    github.String(ref),
+			Object: &github.GitObject{SHA: newTag.SHA},
+		}, true); err != nil {
+			return fmt.Errorf("unable to update existing ref %s: %s", ref, err)
+		}
+	case err == nil:
+		return fmt.Errorf("tag %q already exists; set `force = true` to replace it", tag)
+	case resp != nil && resp.StatusCode == 404:
+		if _, _, createErr := client.Git.CreateRef(ctx, owner, repo, &github.Reference{
+			Ref:    github.String(ref),
+			Object: &github.GitObject{SHA: newTag.SHA},
+		}); createErr != nil {
+			return fmt.Errorf("unable to create ref %s: %s", ref, createErr)
+		}
+	default:
+		return fmt.Errorf("unable to check for existing ref %s: %s", ref, err)
+	}
+
+	d.SetId(buildTwoPartID(repo, tag))
+	d.Set("sha", newTag.GetSHA())
+	d.Set("tagger_name", taggerName)
+	d.Set("tagger_email", taggerEmail)
+
+	return resourceGithubRepositorySignedTagRead(d, meta)
+}
+
+func resourceGithubRepositorySignedTagRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+
+	repo, tag, err := parseTwoPartID(d.Id(), "repository", "tag")
+	if err != nil {
+		return err
+	}
+
+	ref, resp, err := client.Git.GetRef(ctx, owner, repo, "refs/tags/"+tag)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.Printf("[INFO] Removing repository signed tag %s from state because it no longer exists in GitHub", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	tagObject, _, err := client.Git.GetTag(ctx, owner, repo, ref.GetObject().GetSHA())
+	if err != nil {
+		return err
+	}
+
+	d.Set("repository", repo)
+	d.Set("tag", tag)
+	d.Set("object_sha", tagObject.GetObject().GetSHA())
+	d.Set("message", tagObject.GetMessage())
+	d.Set("tagger_name", tagObject.GetTagger().GetName())
+	d.Set("tagger_email", tagObject.GetTagger().GetEmail())
+	d.Set("sha", tagObject.GetSHA())
+
+	return nil
+}
+
+func resourceGithubRepositorySignedTagDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	ctx := context.Background()
+
+	repo, tag, err := parseTwoPartID(d.Id(), "repository", "tag")
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Git.DeleteRef(ctx, owner, repo, "refs/tags/"+tag)
+	return err
+}
@@ -0,0 +1,68 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+// TestAccGithubRepositorySignedTag_force creates a tag with `force = false`,
+// through the branch of resourceGithubRepositorySignedTagCreate that finds
+// no existing `refs/tags/<tag>` ref and creates it, then sets `force = true`
+// on the same resource, which is applied in place without re-creating the
+// tag. The ref-already-exists branch of Create is not reached by this test.
+func TestAccGithubRepositorySignedTag_force(t *testing.T) {
+	if os.Getenv("GITHUB_GPG_SECRET_KEY") == "" {
+		t.Skip("GITHUB_GPG_SECRET_KEY must be set to exercise signed tags")
+	}
+
+	testAccPreCheck(t)
+	repo := testAccRepositoryName(t)
+	objectSHA := testAccRepositoryHeadSHA(t, repo)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGithubRepositorySignedTagConfig(repo, objectSHA, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("github_repository_signed_tag.test", "sha"),
+				),
+			},
+			{
+				Config: testAccGithubRepositorySignedTagConfig(repo, objectSHA, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("github_repository_signed_tag.test", "sha"),
+				),
+			},
+		},
+	})
+}
+
+func testAccGithubRepositorySignedTagConfig(repo, objectSHA string, force bool) string {
+	return fmt.Sprintf(`
+resource "github_repository_signed_tag" "test" {
+  repository = %q
+  tag        = "v0.0.1-terraform-acc-test"
+  object_sha = %q
+  message    = "Acceptance test tag"
+  force      = %t
+}
+`, repo, objectSHA, force)
+}
+
+func testAccRepositoryHeadSHA(t *testing.T, repo string) string {
+	client := testAccProvider.Meta().(*Owner).v3client
+	owner := testAccProvider.Meta().(*Owner).name
+
+	ref, _, err := client.Git.GetRef(context.Background(), owner, repo, "refs/heads/main")
+	if err != nil {
+		t.Fatalf("unable to look up HEAD of %s: %s", repo, err)
+	}
+
+	return ref.GetObject().GetSHA()
+}